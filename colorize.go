@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"io"
+)
+
+// Theme controls the ANSI color codes used for each class of JSON token. An empty field
+// disables coloring for that class (useful for a no-color or monochrome theme); Reset is
+// written after every colored token.
+type Theme struct {
+	Key         string
+	String      string
+	Number      string
+	Bool        string
+	Null        string
+	Punctuation string
+	Reset       string
+}
+
+// DefaultTheme matches the palette historically produced by syntaxHighlight.
+var DefaultTheme = Theme{
+	Key:         ColorBlue + ColorBold,
+	String:      ColorGreen,
+	Number:      ColorYellow,
+	Bool:        ColorPurple,
+	Null:        ColorRed,
+	Punctuation: "",
+	Reset:       ColorReset,
+}
+
+// Colorize walks src as a single pass of JSON bytes and writes an ANSI-colorized copy to
+// dst, using theme to pick the color for each token. Unlike a regex-based highlighter, it
+// tracks string/number/literal boundaries itself, so colons and numbers embedded inside
+// string values are never mistaken for structural tokens.
+func Colorize(dst io.Writer, src []byte, theme Theme) error {
+	i, n := 0, len(src)
+
+	for i < n {
+		c := src[i]
+		switch {
+		case isWhitespaceByte(c):
+			start := i
+			i++
+			for i < n && isWhitespaceByte(src[i]) {
+				i++
+			}
+			if _, err := dst.Write(src[start:i]); err != nil {
+				return err
+			}
+
+		case c == '"':
+			start := i
+			i++
+			for i < n {
+				if src[i] == '\\' {
+					if i+1 < n && src[i+1] == 'u' {
+						i += 6
+					} else {
+						i += 2
+					}
+					continue
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				i++
+			}
+			if i > n {
+				i = n
+			}
+			tok := src[start:i]
+
+			j := i
+			for j < n && isWhitespaceByte(src[j]) {
+				j++
+			}
+			color := theme.String
+			if j < n && src[j] == ':' {
+				color = theme.Key
+			}
+			if err := writeToken(dst, color, tok, theme.Reset); err != nil {
+				return err
+			}
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := i
+			i++
+			for i < n && isNumberByte(src[i]) {
+				i++
+			}
+			if err := writeToken(dst, theme.Number, src[start:i], theme.Reset); err != nil {
+				return err
+			}
+
+		case c == 't' && matchesLiteral(src[i:], "true"):
+			if err := writeToken(dst, theme.Bool, src[i:i+4], theme.Reset); err != nil {
+				return err
+			}
+			i += 4
+
+		case c == 'f' && matchesLiteral(src[i:], "false"):
+			if err := writeToken(dst, theme.Bool, src[i:i+5], theme.Reset); err != nil {
+				return err
+			}
+			i += 5
+
+		case c == 'n' && matchesLiteral(src[i:], "null"):
+			if err := writeToken(dst, theme.Null, src[i:i+4], theme.Reset); err != nil {
+				return err
+			}
+			i += 4
+
+		default:
+			if err := writeToken(dst, theme.Punctuation, src[i:i+1], theme.Reset); err != nil {
+				return err
+			}
+			i++
+		}
+	}
+
+	return nil
+}
+
+// isWhitespaceByte reports whether b is JSON insignificant whitespace.
+func isWhitespaceByte(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// isNumberByte reports whether b can appear inside a JSON number (digits, sign, decimal
+// point, or exponent marker), once a number token has already started.
+func isNumberByte(b byte) bool {
+	switch {
+	case b >= '0' && b <= '9':
+		return true
+	case b == '.' || b == '+' || b == '-' || b == 'e' || b == 'E':
+		return true
+	}
+	return false
+}
+
+// matchesLiteral reports whether src starts with the exact bytes of lit.
+func matchesLiteral(src []byte, lit string) bool {
+	if len(src) < len(lit) {
+		return false
+	}
+	for i := 0; i < len(lit); i++ {
+		if src[i] != lit[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeToken writes tok to dst wrapped in color/reset, skipping the wrapper entirely when
+// color is empty so a no-color theme produces byte-identical output to the source.
+func writeToken(dst io.Writer, color string, tok []byte, reset string) error {
+	if color == "" {
+		_, err := dst.Write(tok)
+		return err
+	}
+	if _, err := io.WriteString(dst, color); err != nil {
+		return err
+	}
+	if _, err := dst.Write(tok); err != nil {
+		return err
+	}
+	_, err := io.WriteString(dst, reset)
+	return err
+}
+
+// syntaxHighlight colorizes pretty-printed JSON using the default theme. Kept as a thin
+// wrapper over Colorize for existing call sites.
+func syntaxHighlight(js []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(js))
+	if err := Colorize(&buf, js, DefaultTheme); err != nil {
+		return js
+	}
+	return buf.Bytes()
+}
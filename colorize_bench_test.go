@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+// legacySyntaxHighlight is the pre-tokenizer regex-based highlighter this package used to
+// ship, kept here only so BenchmarkColorize has something to compare Colorize against.
+func legacySyntaxHighlight(js []byte) []byte {
+	str := string(js)
+
+	keyColor := ColorBlue + ColorBold
+	stringColor := ColorGreen
+	numberColor := ColorYellow
+	boolColor := ColorPurple
+	nullColor := ColorRed
+	reset := ColorReset
+
+	reKey := regexp.MustCompile(`"([^"]+)"\s*:`)
+	str = reKey.ReplaceAllString(str, keyColor+`"$1"`+reset+`:`)
+
+	reString := regexp.MustCompile(`:(\s*)"([^"]*)"`)
+	str = reString.ReplaceAllString(str, `:`+`$1`+stringColor+`"$2"`+reset)
+
+	reNum := regexp.MustCompile(`:(\s*)([0-9]+(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?)`)
+	str = reNum.ReplaceAllString(str, `:`+`$1`+numberColor+`$2`+reset)
+
+	reBool := regexp.MustCompile(`:(\s*)(true|false)`)
+	str = reBool.ReplaceAllString(str, `:`+`$1`+boolColor+`$2`+reset)
+
+	reNull := regexp.MustCompile(`:(\s*)(null)`)
+	str = reNull.ReplaceAllString(str, `:`+`$1`+nullColor+`$2`+reset)
+
+	return []byte(str)
+}
+
+// largeBenchmarkInput builds a deeply-nested, mixed-type document large enough to show the
+// difference between an allocation-heavy regex pipeline and a single-pass tokenizer.
+func largeBenchmarkInput(b *testing.B) []byte {
+	b.Helper()
+	type item struct {
+		ID     int      `json:"id"`
+		Name   string   `json:"name"`
+		Price  float64  `json:"price"`
+		Active bool     `json:"active"`
+		Tags   []string `json:"tags"`
+		Note   *string  `json:"note"`
+	}
+	items := make([]item, 2000)
+	for i := range items {
+		items[i] = item{
+			ID:     i,
+			Name:   "widget: with a colon, and \"quotes\"",
+			Price:  19.99,
+			Active: i%2 == 0,
+			Tags:   []string{"a", "b", "c"},
+			Note:   nil,
+		}
+	}
+	pretty, err := json.MarshalIndent(map[string]interface{}{"items": items}, "", "  ")
+	if err != nil {
+		b.Fatalf("marshal fixture: %v", err)
+	}
+	return pretty
+}
+
+func BenchmarkColorizeTokenizer(b *testing.B) {
+	src := largeBenchmarkInput(b)
+	b.ResetTimer()
+	b.ReportAllocs()
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := Colorize(&buf, src, DefaultTheme); err != nil {
+			b.Fatalf("Colorize: %v", err)
+		}
+	}
+}
+
+func BenchmarkColorizeLegacyRegex(b *testing.B) {
+	src := largeBenchmarkInput(b)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = legacySyntaxHighlight(src)
+	}
+}
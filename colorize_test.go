@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColorizeNoColorTheme(t *testing.T) {
+	// A theme with every field empty must reproduce the source bytes exactly, since
+	// writeToken skips the color wrapper when color == "".
+	src := []byte(" {\n  \"a\" : 1,\n  \"b\": true,\n  \"c\":null,\n  \"d\":\"x: y\"\n} ")
+
+	var buf bytes.Buffer
+	if err := Colorize(&buf, src, Theme{}); err != nil {
+		t.Fatalf("Colorize: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), src) {
+		t.Fatalf("Colorize with empty theme = %q, want %q", buf.Bytes(), src)
+	}
+}
+
+func TestColorizeTokenClasses(t *testing.T) {
+	src := []byte(`{"key":"value","n":-1.5e10,"t":true,"f":false,"z":null}`)
+
+	theme := Theme{
+		Key:    "K",
+		String: "S",
+		Number: "N",
+		Bool:   "B",
+		Null:   "Z",
+		Reset:  "R",
+	}
+
+	var buf bytes.Buffer
+	if err := Colorize(&buf, src, theme); err != nil {
+		t.Fatalf("Colorize: %v", err)
+	}
+
+	want := `{K"key"R:S"value"R,K"n"R:N-1.5e10R,K"t"R:Btrue` +
+		`R,K"f"R:BfalseR,K"z"R:ZnullR}`
+	if got := buf.String(); got != want {
+		t.Fatalf("Colorize =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestColorizeLiteralLookalikes checks that keys and strings whose text starts with
+// "true"/"false"/"null" stay key- or string-colored, not be mistaken for the
+// true/false/null literals.
+func TestColorizeLiteralLookalikes(t *testing.T) {
+	src := []byte(`{"trueish":"falsey","nullable":1}`)
+
+	theme := Theme{Key: "K", String: "S", Number: "N", Reset: "R"}
+
+	var buf bytes.Buffer
+	if err := Colorize(&buf, src, theme); err != nil {
+		t.Fatalf("Colorize: %v", err)
+	}
+
+	want := `{K"trueish"R:S"falsey"R,K"nullable"R:N1R}`
+	if got := buf.String(); got != want {
+		t.Fatalf("Colorize =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gigachad80/JSON-Beautify/pkg/diff"
+)
+
+var (
+	diffFile   = flag.String("diff", "", "Compare the primary input against this JSON file and emit a patch instead of beautified output")
+	diffFormat = flag.String("diff-format", "patch", "Diff output dialect: patch (RFC 6902 JSON Patch) or gron (gron-style +/-)")
+)
+
+// runDiffMode compares every resolved source against -diff in turn (see diffOne) and
+// exits the process directly: 0 if every pair across every source is identical, 1 if any
+// pair differs, 2 on error — matching diff(1)'s convention so it composes in CI.
+func runDiffMode(sources []Source, writer io.Writer) {
+	if len(sources) == 0 {
+		printError("no primary input provided for -diff")
+		os.Exit(2)
+	}
+	if *diffFormat != "patch" && *diffFormat != "gron" {
+		printError(fmt.Sprintf("unknown -diff-format %q: expected \"patch\" or \"gron\"", *diffFormat))
+		os.Exit(2)
+	}
+
+	anyDiff := false
+	for _, src := range sources {
+		if diffOne(src, writer) {
+			anyDiff = true
+		}
+	}
+
+	if anyDiff {
+		os.Exit(1)
+	}
+}
+
+// diffOne compares a single primary source against -diff document-by-document (pairwise
+// across NDJSON streams) and reports whether any pair differed.
+func diffOne(src Source, writer io.Writer) bool {
+	rc, label, err := src.Open()
+	if err != nil {
+		printError(err.Error())
+		os.Exit(2)
+	}
+	defer rc.Close()
+	if label != "" {
+		printInfo(fmt.Sprintf("Processing: %s", label))
+	}
+
+	otherRC, _, err := (fileSource{path: *diffFile}).Open()
+	if err != nil {
+		printError(err.Error())
+		os.Exit(2)
+	}
+	defer otherRC.Close()
+
+	decA := json.NewDecoder(rc)
+	decA.UseNumber()
+	decB := json.NewDecoder(otherRC)
+	decB.UseNumber()
+
+	anyDiff := false
+	for {
+		var va, vb interface{}
+		errA := decA.Decode(&va)
+		errB := decB.Decode(&vb)
+		doneA := errors.Is(errA, io.EOF)
+		doneB := errors.Is(errB, io.EOF)
+		if doneA && doneB {
+			break
+		}
+		if errA != nil && !doneA {
+			printError(fmt.Sprintf("Invalid JSON in primary input: %v", errA))
+			os.Exit(2)
+		}
+		if errB != nil && !doneB {
+			printError(fmt.Sprintf("Invalid JSON in %s: %v", *diffFile, errB))
+			os.Exit(2)
+		}
+		if doneA {
+			va = nil
+		}
+		if doneB {
+			vb = nil
+		}
+
+		switch *diffFormat {
+		case "gron":
+			lines := diff.GronLines(va, vb)
+			if len(lines) > 0 {
+				anyDiff = true
+			}
+			for _, line := range lines {
+				text := line.Sign + " " + line.Text
+				if *colorize {
+					color := ColorRed
+					if line.Sign == "+" {
+						color = ColorGreen
+					}
+					text = color + text + ColorReset
+				}
+				fmt.Fprintln(writer, text)
+			}
+		default:
+			ops := diff.Diff(va, vb)
+			if len(ops) > 0 {
+				anyDiff = true
+			}
+			patch, err := json.Marshal(ops)
+			if err != nil {
+				printError(fmt.Sprintf("Encoding patch: %v", err))
+				os.Exit(2)
+			}
+			fmt.Fprintln(writer, string(patch))
+		}
+	}
+
+	return anyDiff
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestHelperProcess isn't a real test: it's re-executed as a subprocess by
+// TestDiffModeExitCodes so -diff's os.Exit(0/1/2) calls can be observed without killing the
+// real test binary. Everything after "--" in its argv becomes main()'s command-line flags.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("JSON_BEAUTIFY_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for i, a := range args {
+		if a == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	os.Args = append([]string{"json-beautify"}, args...)
+	main()
+}
+
+// runDiffSubprocess runs this test binary as a "json-beautify <flags...>" subprocess and
+// returns its exit code.
+func runDiffSubprocess(t *testing.T, flags ...string) int {
+	t.Helper()
+	args := append([]string{"-test.run=^TestHelperProcess$", "--"}, flags...)
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "JSON_BEAUTIFY_HELPER_PROCESS=1")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return 0
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("subprocess failed to run: %v\noutput: %s", err, out)
+	}
+	return exitErr.ExitCode()
+}
+
+// TestDiffModeExitCodesIdentical checks -diff's diff(1)-style exit code contract: 0 when
+// every compared document is identical.
+func TestDiffModeExitCodesIdentical(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTempJSON(t, dir, "a.json", `{"x":1}`)
+	b := writeTempJSON(t, dir, "b.json", `{"x":1}`)
+
+	if code := runDiffSubprocess(t, "-i", a, "-diff", b); code != 0 {
+		t.Fatalf("exit code = %d, want 0 for identical documents", code)
+	}
+}
+
+// TestDiffModeExitCodesDiffer checks the exit code is 1 when the compared documents differ.
+func TestDiffModeExitCodesDiffer(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTempJSON(t, dir, "a.json", `{"x":1}`)
+	b := writeTempJSON(t, dir, "b.json", `{"x":2}`)
+
+	if code := runDiffSubprocess(t, "-i", a, "-diff", b); code != 1 {
+		t.Fatalf("exit code = %d, want 1 for differing documents", code)
+	}
+}
+
+// TestDiffModeExitCodesError checks the exit code is 2 when -diff itself can't proceed,
+// e.g. an unknown -diff-format value.
+func TestDiffModeExitCodesError(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTempJSON(t, dir, "a.json", `{"x":1}`)
+	b := writeTempJSON(t, dir, "b.json", `{"x":1}`)
+
+	if code := runDiffSubprocess(t, "-i", a, "-diff", b, "-diff-format", "yaml"); code != 2 {
+		t.Fatalf("exit code = %d, want 2 for an unknown -diff-format", code)
+	}
+}
+
+// TestDiffModeExitCodesBigIntegerPrecision is a regression test: -diff must decode with
+// UseNumber like the rest of the codebase, or ids beyond float64's exact-integer range
+// (>2^53) round to the same float and a real difference is reported as identical.
+func TestDiffModeExitCodesBigIntegerPrecision(t *testing.T) {
+	dir := t.TempDir()
+	a := writeTempJSON(t, dir, "a.json", `{"id":9007199254740993}`)
+	b := writeTempJSON(t, dir, "b.json", `{"id":9007199254740995}`)
+
+	if code := runDiffSubprocess(t, "-i", a, "-diff", b); code != 1 {
+		t.Fatalf("exit code = %d, want 1 for differing big-integer ids", code)
+	}
+}
+
+func writeTempJSON(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gigachad80/JSON-Beautify/pkg/gron"
+)
+
+// gronSegment is one step of a gron path: either a bare/quoted object key or an array index.
+type gronSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// gronLines converts a decoded document to its full set of gron assignment lines, sorting
+// them when sorted is true.
+func gronLines(data interface{}, sorted bool) []string {
+	assignments := gron.Flatten("json", data)
+	lines := make([]string, len(assignments))
+	for i, a := range assignments {
+		lines[i] = a.Path + " = " + a.Literal + ";"
+	}
+	if sorted {
+		sort.Strings(lines)
+	}
+	return lines
+}
+
+// gronHighlight colorizes a single "path = literal;" assignment line through the same
+// Theme/Colorize machinery as syntaxHighlight, with the same DefaultTheme palette used for
+// pretty-printed JSON: the path in key color, the literal colorized by Colorize according
+// to its JSON type.
+func gronHighlight(line string) string {
+	eq := strings.Index(line, " = ")
+	if eq == -1 || !strings.HasSuffix(line, ";") {
+		return line
+	}
+	path := line[:eq]
+	literal := line[eq+3 : len(line)-1]
+
+	var buf bytes.Buffer
+	if err := writeToken(&buf, DefaultTheme.Key, []byte(path), DefaultTheme.Reset); err != nil {
+		return line
+	}
+	buf.WriteString(" = ")
+	if err := Colorize(&buf, []byte(literal), DefaultTheme); err != nil {
+		return line
+	}
+	buf.WriteString(";")
+
+	return buf.String()
+}
+
+// parseGronPath splits the left-hand side of an assignment (e.g. json.a["b c"][0]) into
+// its root and a sequence of key/index segments.
+func parseGronPath(path string) (string, []gronSegment, error) {
+	if path == "" {
+		return "", nil, fmt.Errorf("empty path")
+	}
+	i := 0
+	for i < len(path) && path[i] != '.' && path[i] != '[' {
+		i++
+	}
+	root := path[:i]
+	var segs []gronSegment
+
+	for i < len(path) {
+		switch path[i] {
+		case '.':
+			i++
+			start := i
+			for i < len(path) && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if start == i {
+				return "", nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			segs = append(segs, gronSegment{key: path[start:i]})
+		case '[':
+			closeAt, err := findBracketClose(path, i)
+			if err != nil {
+				return "", nil, err
+			}
+			inner := path[i+1 : closeAt]
+			i = closeAt + 1
+			if n, err := strconv.Atoi(inner); err == nil {
+				segs = append(segs, gronSegment{index: n, isIndex: true})
+			} else {
+				var key string
+				if err := json.Unmarshal([]byte(inner), &key); err != nil {
+					return "", nil, fmt.Errorf("invalid path %q: bad bracket key %q", path, inner)
+				}
+				segs = append(segs, gronSegment{key: key})
+			}
+		default:
+			return "", nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return root, segs, nil
+}
+
+// findBracketClose returns the index of the ']' that closes the bracket segment opened at
+// path[open] (path[open] == '['). When the bracket holds a JSON-quoted key, the closing
+// quote is found by scanning the string literal itself (honoring \" and \\ escapes) rather
+// than a raw IndexByte scan, so a literal ']' inside the key (e.g. json["a]b"]) doesn't
+// terminate the bracket early.
+func findBracketClose(path string, open int) (int, error) {
+	i := open + 1
+	if i < len(path) && path[i] == '"' {
+		i++
+		for i < len(path) {
+			switch path[i] {
+			case '\\':
+				i += 2
+				continue
+			case '"':
+				i++
+				if i < len(path) && path[i] == ']' {
+					return i, nil
+				}
+				return -1, fmt.Errorf("invalid path %q: unterminated [", path)
+			}
+			i++
+		}
+		return -1, fmt.Errorf("invalid path %q: unterminated [", path)
+	}
+
+	end := strings.IndexByte(path[open:], ']')
+	if end == -1 {
+		return -1, fmt.Errorf("invalid path %q: unterminated [", path)
+	}
+	return open + end, nil
+}
+
+// fromGron reconstructs a single JSON document from a set of "path = literal;" assignment
+// lines, merging each one into a tree. Arrays are built sparsely and emitted in index order.
+func fromGron(lines []string) (interface{}, error) {
+	var root interface{}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		eq := strings.Index(line, " = ")
+		if eq == -1 || !strings.HasSuffix(line, ";") {
+			return nil, fmt.Errorf("invalid gron line: %q", line)
+		}
+		path := line[:eq]
+		literalStr := line[eq+3 : len(line)-1]
+
+		var literal interface{}
+		dec := json.NewDecoder(strings.NewReader(literalStr))
+		dec.UseNumber()
+		if err := dec.Decode(&literal); err != nil {
+			return nil, fmt.Errorf("invalid literal in line %q: %w", line, err)
+		}
+
+		_, segs, err := parseGronPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(segs) == 0 {
+			root = literal
+			continue
+		}
+
+		root, err = gronAssign(root, segs, literal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// gronAssign sets value at the location described by segs within node, creating any
+// intermediate maps/slices as needed, and returns the (possibly new) node.
+func gronAssign(node interface{}, segs []gronSegment, value interface{}) (interface{}, error) {
+	seg := segs[0]
+	rest := segs[1:]
+
+	if seg.isIndex {
+		slice, ok := node.([]interface{})
+		if !ok {
+			slice = nil
+		}
+		for len(slice) <= seg.index {
+			slice = append(slice, nil)
+		}
+		child := slice[seg.index]
+		if len(rest) == 0 {
+			slice[seg.index] = value
+		} else {
+			updated, err := gronAssign(child, rest, value)
+			if err != nil {
+				return nil, err
+			}
+			slice[seg.index] = updated
+		}
+		return slice, nil
+	}
+
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		obj = make(map[string]interface{})
+	}
+	if len(rest) == 0 {
+		obj[seg.key] = value
+	} else {
+		updated, err := gronAssign(obj[seg.key], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		obj[seg.key] = updated
+	}
+	return obj, nil
+}
+
+// runUngron reads gron-style assignment lines from reader and reconstructs JSON documents,
+// writing each one through the same compact/indent/colorize/-o path as the normal pipeline.
+// Documents in a gron stream (one per original NDJSON entry) are separated by blank lines.
+func runUngron(reader io.Reader, writer io.Writer) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var block []string
+	var flushErr error
+
+	flush := func() {
+		if len(block) == 0 || flushErr != nil {
+			return
+		}
+		doc, err := fromGron(block)
+		if err != nil {
+			flushErr = fmt.Errorf("invalid gron input: %w", err)
+			return
+		}
+
+		var outputBytes []byte
+		if *compact {
+			outputBytes, err = json.Marshal(doc)
+		} else {
+			outputBytes, err = json.MarshalIndent(doc, *prefix, *indent)
+		}
+		if err != nil {
+			flushErr = fmt.Errorf("encoding error: %w", err)
+			return
+		}
+
+		if *colorize && *outputFile == "" {
+			outputBytes = syntaxHighlight(outputBytes)
+		}
+
+		writer.Write(outputBytes)
+		writer.Write([]byte(*sep))
+		block = block[:0]
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			if flushErr != nil {
+				return flushErr
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+	if flushErr != nil {
+		return flushErr
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gigachad80/JSON-Beautify/pkg/query"
+)
+
+// TestProcessStreamGronIgnoresSepBetweenLines is a regression test for gron lines being
+// joined by -sep instead of "\n": with a non-default separator, each assignment must still
+// land on its own line, and *sep must appear only once, after the whole document.
+func TestProcessStreamGronIgnoresSepBetweenLines(t *testing.T) {
+	origGron, origSep, origColor := *gronMode, *sep, *colorize
+	*gronMode = true
+	*sep = "###"
+	*colorize = false
+	defer func() {
+		*gronMode, *sep, *colorize = origGron, origSep, origColor
+	}()
+
+	var buf bytes.Buffer
+	_, err := processStream(strings.NewReader(`{"a":1,"b":2}`), &buf, query.Path{}, query.FieldMask{}, false, true)
+	if err != nil {
+		t.Fatalf("processStream: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "\n") < 3 {
+		t.Fatalf("expected each assignment on its own line, got: %q", out)
+	}
+	if !strings.HasSuffix(out, "###") {
+		t.Fatalf("expected -sep to appear once at the end of the document, got: %q", out)
+	}
+	if strings.Count(out, "###") != 1 {
+		t.Fatalf("expected exactly one -sep between documents, got: %q", out)
+	}
+}
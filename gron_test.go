@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// unmarshalUseNumber decodes src the same way the CLI's real input path does (UseNumber),
+// so fixtures exercise the same json.Number representation fromGron now produces.
+func unmarshalUseNumber(t *testing.T, src string) interface{} {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(src))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("unmarshal fixture: %v", err)
+	}
+	return v
+}
+
+// roundTrip flattens data to gron lines and rebuilds it, returning the rebuilt document.
+func roundTrip(t *testing.T, data interface{}) interface{} {
+	t.Helper()
+	lines := gronLines(data, false)
+	doc, err := fromGron(lines)
+	if err != nil {
+		t.Fatalf("fromGron: %v", err)
+	}
+	return doc
+}
+
+// reencode normalizes a value through a JSON marshal/decode cycle (with UseNumber, matching
+// fromGron) so map key order and number representations match what round tripping through
+// gron produces.
+func reencode(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return unmarshalUseNumber(t, string(b))
+}
+
+func TestGronRoundTrip(t *testing.T) {
+	cases := map[string]string{
+		"scalars":    `{"a":1,"b":true,"c":null,"d":"hello"}`,
+		"unicode":    `{"naïve":1,"日本語":"値","emoji 🎉":true}`,
+		"deepNested": `{"a":{"b":{"c":[1,2,{"d":"e"}]}}}`,
+		"weirdKeys":  `{"with space":1,"with.dot":2}`,
+		"arrays":     `{"items":[1,"two",[3,4],{"five":5}]}`,
+	}
+
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			data := unmarshalUseNumber(t, src)
+
+			got := roundTrip(t, data)
+			want := reencode(t, data)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("round trip mismatch:\n got:  %#v\n want: %#v", got, want)
+			}
+		})
+	}
+}
+
+// TestGronRoundTripNumberSourceText is a regression test: -ungron must decode literals with
+// UseNumber too, not just -gron flatten with it, or numbers that don't round-trip through
+// float64 (values beyond float64's exact integer range, trailing zeros) get silently
+// mangled on the way back.
+func TestGronRoundTripNumberSourceText(t *testing.T) {
+	src := `{"bigint":9007199254740993,"trailingZero":19.990}`
+	data := unmarshalUseNumber(t, src)
+
+	doc := roundTrip(t, data)
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal round-tripped doc: %v", err)
+	}
+	if got := string(b); got != src {
+		t.Fatalf("round trip mangled number source text:\n got:  %s\n want: %s", got, src)
+	}
+}
+
+// TestGronRoundTripBracketKeyWithCloseBracket is a regression test: a key containing a
+// literal ']' flattens fine (json["a]b"] = 1;) but used to fail to parse back because
+// parseGronPath found the first ']' byte instead of the one that actually closes the
+// JSON-quoted key.
+func TestGronRoundTripBracketKeyWithCloseBracket(t *testing.T) {
+	data := unmarshalUseNumber(t, `{"a]b":1}`)
+
+	got := roundTrip(t, data)
+	want := reencode(t, data)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip mismatch:\n got:  %#v\n want: %#v", got, want)
+	}
+}
+
+// TestGronRoundTripArrayHoles checks that ungron fills skipped indices with null when the
+// gron assignments for an array aren't contiguous, matching gron(1)'s sparse-array handling.
+func TestGronRoundTripArrayHoles(t *testing.T) {
+	lines := []string{
+		`json = {};`,
+		`json.items = [];`,
+		`json.items[0] = 1;`,
+		`json.items[2] = 3;`,
+	}
+
+	doc, err := fromGron(lines)
+	if err != nil {
+		t.Fatalf("fromGron: %v", err)
+	}
+
+	want := reencode(t, map[string]interface{}{
+		"items": []interface{}{json.Number("1"), nil, json.Number("3")},
+	})
+	if !reflect.DeepEqual(doc, want) {
+		t.Fatalf("sparse array mismatch:\n got:  %#v\n want: %#v", doc, want)
+	}
+}
+
+// TestRunUngronNDJSON verifies that gron output for multiple NDJSON documents round-trips
+// through -ungron without the documents merging into one (regression for the missing
+// blank-line separator between documents).
+func TestRunUngronNDJSON(t *testing.T) {
+	docs := []string{`{"a":1}`, `{"b":2}`}
+
+	origColor, origSep := *colorize, *sep
+	*colorize, *sep = false, "\n"
+	defer func() {
+		*colorize, *sep = origColor, origSep
+	}()
+
+	var input strings.Builder
+	for _, d := range docs {
+		data := unmarshalUseNumber(t, d)
+		for _, line := range gronLines(data, false) {
+			input.WriteString(line + "\n")
+		}
+		input.WriteString("\n")
+	}
+
+	var out bytes.Buffer
+	if err := runUngron(strings.NewReader(input.String()), &out); err != nil {
+		t.Fatalf("runUngron: %v", err)
+	}
+
+	var got []interface{}
+	dec := json.NewDecoder(&out)
+	dec.UseNumber()
+	for {
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			t.Fatalf("decode runUngron output: %v", err)
+		}
+		got = append(got, doc)
+	}
+
+	if len(got) != len(docs) {
+		t.Fatalf("expected %d documents, got %d: %#v", len(docs), len(got), got)
+	}
+	for i, d := range docs {
+		want := unmarshalUseNumber(t, d)
+		if !reflect.DeepEqual(got[i], want) {
+			t.Fatalf("document %d mismatch:\n got:  %#v\n want: %#v", i, got[i], want)
+		}
+	}
+}
+
+// TestGronHighlightUsesTheme is a regression test: gronHighlight must route through the
+// same Theme/Colorize machinery as syntaxHighlight, not a hand-picked palette, so -gron
+// output is colored with DefaultTheme just like pretty-printed JSON.
+func TestGronHighlightUsesTheme(t *testing.T) {
+	got := gronHighlight(`json.a = "hi";`)
+	want := DefaultTheme.Key + "json.a" + DefaultTheme.Reset + " = " +
+		DefaultTheme.String + `"hi"` + DefaultTheme.Reset + ";"
+	if got != want {
+		t.Fatalf("gronHighlight =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestGronHighlightMalformedLine guards the early-return path for lines that don't match
+// the "path = literal;" shape.
+func TestGronHighlightMalformedLine(t *testing.T) {
+	line := "not a gron line"
+	if got := gronHighlight(line); got != line {
+		t.Fatalf("gronHighlight(malformed) = %q, want unchanged %q", got, line)
+	}
+}
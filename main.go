@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"regexp"
+
+	"github.com/gigachad80/JSON-Beautify/pkg/canonical"
+	"github.com/gigachad80/JSON-Beautify/pkg/query"
 )
 
 // ANSI color codes
@@ -40,6 +42,18 @@ var (
 	validate = flag.Bool("v", false, "Validate JSON only (no output)")
 	help     = flag.Bool("h", false, "Show help menu")
 	version  = flag.Bool("version", false, "Show version")
+
+	// gron mode flags
+	gronMode   = flag.Bool("gron", false, "Output flattened gron-style path assignments")
+	ungronMode = flag.Bool("ungron", false, "Read gron-style path assignments and rebuild JSON")
+
+	// Query / field selection flags
+	queryPath = flag.String("q", "", "gjson-like path query to apply before output")
+	fieldMask = flag.String("fields", "", "AIP-157 field mask to prune the document to (comma-separated dotted paths)")
+	strict    = flag.Bool("strict", false, "Exit non-zero when -q resolves to nothing")
+
+	// Canonical output flag
+	canonicalMode = flag.Bool("canonical", false, "Emit RFC 8785 canonical JSON (JCS); overrides -indent/-prefix/-c")
 )
 
 const VERSION = "1.1.0"
@@ -57,25 +71,11 @@ func main() {
 		return
 	}
 
-	// 1. Determine Input Source
-	var reader io.Reader
-	if *inputFile != "" {
-		f, err := os.Open(*inputFile)
-		if err != nil {
-			printError(fmt.Sprintf("Error opening file: %v", err))
-			os.Exit(1)
-		}
-		defer f.Close()
-		reader = f
-		printInfo(fmt.Sprintf("Processing: %s", *inputFile))
-	} else {
-		// Check stdin
-		stat, _ := os.Stdin.Stat()
-		if (stat.Mode() & os.ModeCharDevice) != 0 {
-			printError("No input provided. Use -i or pipe data.")
-			os.Exit(1)
-		}
-		reader = os.Stdin
+	// 1. Determine Input Source(s)
+	sources, err := resolveSources()
+	if err != nil {
+		printError(err.Error())
+		os.Exit(1)
 	}
 
 	// 2. Prepare Output
@@ -99,31 +99,119 @@ func main() {
 		}
 	}
 
-	// 3. Process the Stream
-	decoder := json.NewDecoder(reader)
-	encoder := json.NewEncoder(writer)
+	// 3. -diff compares the primary input against another document instead of
+	// beautifying it, so it bypasses the rest of the pipeline entirely.
+	if *diffFile != "" {
+		runDiffMode(sources, writer)
+		return
+	}
+
+	// 4. -ungron reads gron assignments, not JSON, so it gets its own stream handling.
+	if *ungronMode {
+		for _, src := range sources {
+			rc, label, err := src.Open()
+			if err != nil {
+				printError(err.Error())
+				if *keepGoing {
+					continue
+				}
+				os.Exit(1)
+			}
+			if label != "" {
+				printInfo(fmt.Sprintf("Processing: %s", label))
+			}
+			err = runUngron(rc, writer)
+			rc.Close()
+			if err != nil {
+				printError(err.Error())
+				if !*keepGoing {
+					os.Exit(1)
+				}
+			}
+		}
+		if *outputFile != "" {
+			printSuccess(fmt.Sprintf("Saved to: %s", *outputFile))
+		}
+		return
+	}
+
+	// Parse the -q query once; it's reused for every document across every source.
+	var parsedQuery query.Path
+	if *queryPath != "" {
+		parsedQuery, err = query.Parse(*queryPath)
+		if err != nil {
+			printError(err.Error())
+			os.Exit(1)
+		}
+	}
+	var mask query.FieldMask
+	if *fieldMask != "" {
+		mask = query.ParseFieldMask(*fieldMask)
+	}
+	if *canonicalMode && (*compact || *prefix != "" || *indent != "  ") {
+		printWarning("-canonical ignores -indent/-prefix/-c; emitting RFC 8785 canonical JSON")
+	}
+
+	// Filtering produces a new tree, so anything that needs -q/-fields must decode into
+	// interface{} like -s and -gron already do.
+	filtering := *queryPath != "" || *fieldMask != ""
+	structured := *sortKeys || *gronMode || filtering || *canonicalMode
+
+	count := 0
+	for _, src := range sources {
+		rc, label, err := src.Open()
+		if err != nil {
+			printError(err.Error())
+			if *keepGoing {
+				continue
+			}
+			os.Exit(1)
+		}
+		if label != "" {
+			printInfo(fmt.Sprintf("Processing: %s", label))
+		}
+
+		n, err := processStream(rc, writer, parsedQuery, mask, filtering, structured)
+		rc.Close()
+		count += n
+		if err != nil {
+			printError(err.Error())
+			if !*keepGoing {
+				os.Exit(1)
+			}
+		}
+	}
 
-	// Configure Encoder
-	encoder.SetEscapeHTML(false) // Don't escape < > &
-	if !*compact {
-		encoder.SetIndent(*prefix, *indent)
+	if *validate {
+		printSuccess(fmt.Sprintf("âœ“ Validated %d JSON object(s)", count))
+	} else if *outputFile != "" {
+		printSuccess(fmt.Sprintf("Saved to: %s", *outputFile))
 	}
+}
+
+// processStream decodes a single NDJSON stream from reader and writes beautified (or
+// gron-flattened) output to writer, applying -q/-fields filtering along the way. It
+// returns the number of documents processed and stops at the first error encountered.
+func processStream(reader io.Reader, writer io.Writer, parsedQuery query.Path, mask query.FieldMask, filtering, structured bool) (int, error) {
+	decoder := json.NewDecoder(reader)
+	// UseNumber keeps numbers as json.Number (the raw source token) instead of float64,
+	// so paths that round-trip through interface{} (-gron, -q, -fields, -canonical) don't
+	// silently lose precision on integers beyond 2^53 or reformat decimals like 19.990.
+	decoder.UseNumber()
 
-	// We use a loop to handle multiple JSON objects in one stream (ndjson support)
 	count := 0
 	for {
 		var raw json.RawMessage
 		var data interface{}
 
 		// Decode logic
-		if *sortKeys {
-			// To sort, we must decode into interface{}
+		if structured {
+			// To sort, flatten, or filter, we must decode into interface{}
 			if err := decoder.Decode(&data); err != nil {
 				if err == io.EOF {
 					break
 				}
-				printError(fmt.Sprintf("Invalid JSON: %v", err))
-				os.Exit(1)
+				return count, fmt.Errorf("invalid JSON: %w", err)
 			}
 		} else {
 			// To preserve order (default), decode into RawMessage
@@ -131,8 +219,23 @@ func main() {
 				if err == io.EOF {
 					break
 				}
-				printError(fmt.Sprintf("Invalid JSON: %v", err))
-				os.Exit(1)
+				return count, fmt.Errorf("invalid JSON: %w", err)
+			}
+		}
+
+		if filtering {
+			if *queryPath != "" {
+				result, err := query.Apply(data, parsedQuery)
+				if err != nil {
+					if *strict {
+						return count, fmt.Errorf("query %q resolved to nothing: %w", *queryPath, err)
+					}
+					result = nil
+				}
+				data = result
+			}
+			if *fieldMask != "" {
+				data = mask.Prune(data)
 			}
 		}
 
@@ -141,11 +244,41 @@ func main() {
 			continue
 		}
 
+		if *gronMode {
+			// Gron's one-assignment-per-line contract is internal to the format, so lines
+			// are always joined by "\n"; *sep is only the separator between documents,
+			// same as every other mode.
+			for _, line := range gronLines(data, *sortKeys) {
+				if *colorize && *outputFile == "" {
+					line = gronHighlight(line)
+				}
+				writer.Write([]byte(line))
+				writer.Write([]byte("\n"))
+			}
+			writer.Write([]byte(*sep))
+			count++
+			continue
+		}
+
+		if *canonicalMode {
+			outputBytes, err := canonical.Marshal(data)
+			if err != nil {
+				return count, fmt.Errorf("canonical encoding error: %w", err)
+			}
+			if *colorize && *outputFile == "" {
+				outputBytes = syntaxHighlight(outputBytes)
+			}
+			writer.Write(outputBytes)
+			writer.Write([]byte(*sep))
+			count++
+			continue
+		}
+
 		// Encode logic (Output)
 		var err error
 		var outputBytes []byte
 
-		if *sortKeys {
+		if structured {
 			if *compact {
 				outputBytes, err = json.Marshal(data)
 			} else {
@@ -156,8 +289,7 @@ func main() {
 			if *compact {
 				buffer := new(bytes.Buffer)
 				if err := json.Compact(buffer, raw); err != nil {
-					printError(fmt.Sprintf("Compact error: %v", err))
-					os.Exit(1)
+					return count, fmt.Errorf("compact error: %w", err)
 				}
 				outputBytes = buffer.Bytes()
 			} else {
@@ -171,8 +303,7 @@ func main() {
 		}
 
 		if err != nil {
-			printError(fmt.Sprintf("Encoding error: %v", err))
-			os.Exit(1)
+			return count, fmt.Errorf("encoding error: %w", err)
 		}
 
 		// Apply Syntax Highlighting if enabled
@@ -182,52 +313,11 @@ func main() {
 
 		// Write to output
 		writer.Write(outputBytes)
-		writer.Write([]byte("\n"))
+		writer.Write([]byte(*sep))
 		count++
 	}
 
-	if *validate {
-		printSuccess(fmt.Sprintf("âœ“ Validated %d JSON object(s)", count))
-	} else if *outputFile != "" {
-		printSuccess(fmt.Sprintf("Saved to: %s", *outputFile))
-	}
-}
-
-// syntaxHighlight adds ANSI color codes to JSON
-func syntaxHighlight(js []byte) []byte {
-	str := string(js)
-
-	keyColor := ColorBlue + ColorBold
-	stringColor := ColorGreen
-	numberColor := ColorYellow
-	boolColor := ColorPurple
-	nullColor := ColorRed
-	reset := ColorReset
-
-	// Highlight Keys (captured by "key": )
-	reKey := regexp.MustCompile(`"([^"]+)"\s*:`)
-	str = reKey.ReplaceAllString(str, keyColor+`"$1"`+reset+`:`)
-
-	// Highlight Strings (values that are strings, look for "text" not followed by colon)
-	// This regex is tricky to not overlap with keys.
-	// We cheat slightly by doing keys first, which adds ANSI codes,
-	// so the next regex won't match keys because they now contain \033.
-	reString := regexp.MustCompile(`:(\s*)"([^"]*)"`)
-	str = reString.ReplaceAllString(str, `:`+`$1`+stringColor+`"$2"`+reset)
-
-	// Highlight Numbers
-	reNum := regexp.MustCompile(`:(\s*)([0-9]+(?:\.[0-9]+)?(?:[eE][+-]?[0-9]+)?)`)
-	str = reNum.ReplaceAllString(str, `:`+`$1`+numberColor+`$2`+reset)
-
-	// Highlight Booleans
-	reBool := regexp.MustCompile(`:(\s*)(true|false)`)
-	str = reBool.ReplaceAllString(str, `:`+`$1`+boolColor+`$2`+reset)
-
-	// Highlight Null
-	reNull := regexp.MustCompile(`:(\s*)(null)`)
-	str = reNull.ReplaceAllString(str, `:`+`$1`+nullColor+`$2`+reset)
-
-	return []byte(str)
+	return count, nil
 }
 
 // ---------------- Helper Functions ----------------
@@ -242,16 +332,29 @@ func printHelp() {
 `
 	fmt.Print(ColorCyan + ColorBold + banner + ColorReset)
 	fmt.Println(ColorYellow + ColorBold + "\nğŸ“– USAGE:" + ColorReset)
-	fmt.Println("  " + ColorGreen + "go run main.go [OPTIONS]" + ColorReset)
+	fmt.Println("  " + ColorGreen + "go run main.go [OPTIONS] [file|url ...]" + ColorReset)
 
 	fmt.Println(ColorYellow + ColorBold + "\nâš™ï¸  OPTIONS:" + ColorReset)
 
-	printFlag("-i <file>", "Input JSON file (or stdin)")
+	printFlag("-i <file>", "Input JSON file, http(s):// URL, or - for stdin")
 	printFlag("-o <file>", "Output file (stdout if empty)")
+	printFlag("-timeout", "Timeout for http:// / https:// inputs (default 30s)")
+	printFlag("-H <header>", "Extra request header for URL inputs (repeatable)")
+	printFlag("-insecure", "Skip TLS certificate verification for https:// inputs")
+	printFlag("-sep", "Separator written between output documents")
+	printFlag("-keep-going", "Continue to the next input if one fails")
 	printFlag("-c", "Compact/minify JSON")
 	printFlag("-s", "Sort keys alphabetically")
 	printFlag("-color", "Force color output (default: auto)")
 	printFlag("-indent", "Custom indentation (default: 2 spaces)")
+	printFlag("-gron", "Flatten JSON into greppable path assignments")
+	printFlag("-ungron", "Rebuild JSON from path assignments")
+	printFlag("-q <path>", "gjson-like path query (e.g. items.#(price>10).name)")
+	printFlag("-fields <mask>", "AIP-157 field mask to prune to (e.g. user.name,items.*.price)")
+	printFlag("-strict", "Exit non-zero when -q resolves to nothing")
+	printFlag("-canonical", "Emit RFC 8785 canonical JSON (JCS)")
+	printFlag("-diff <file>", "Compare input against file and emit a patch instead of beautified output")
+	printFlag("-diff-format", "Diff dialect: patch (RFC 6902 JSON Patch) or gron (gron-style +/-)")
 	printFlag("-v", "Validate only")
 	printFlag("-h", "Show help")
 
@@ -259,6 +362,10 @@ func printHelp() {
 	fmt.Println("  â€¢ Syntax Highlighting")
 	fmt.Println("  â€¢ Stream Processing (NDJSON support)")
 	fmt.Println("  â€¢ Key Sorting")
+	fmt.Println("  â€¢ gron / ungron Flattening")
+	fmt.Println("  â€¢ Path Queries & Field Masks")
+	fmt.Println("  â€¢ RFC 8785 Canonical JSON (JCS)")
+	fmt.Println("  â€¢ Structural JSON Diff (RFC 6902 / gron-style)")
 }
 
 func printFlag(flag, desc string) {
@@ -280,3 +387,7 @@ func printSuccess(msg string) {
 func printInfo(msg string) {
 	fmt.Fprintf(os.Stderr, ColorBlue+"[â†’] "+ColorReset+"%s\n", msg)
 }
+
+func printWarning(msg string) {
+	fmt.Fprintf(os.Stderr, ColorYellow+ColorBold+"[!] "+ColorReset+ColorYellow+"%s\n"+ColorReset, msg)
+}
@@ -0,0 +1,201 @@
+// Package canonical implements RFC 8785 JSON Canonicalization Scheme (JCS) output:
+// UTF-8, no insignificant whitespace, object keys sorted by UTF-16 code unit, numbers
+// formatted per the ECMA-262 Number::toString algorithm, and a minimal string escape set.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Marshal encodes v as canonical JSON. v is expected to be the kind of tree
+// encoding/json.Unmarshal produces into interface{} (map[string]interface{}, []interface{},
+// string, float64/json.Number, bool, nil).
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeValue(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Transform decodes src as JSON and re-encodes it as canonical JSON.
+func Transform(src []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(src, &v); err != nil {
+		return nil, fmt.Errorf("canonical: invalid JSON: %w", err)
+	}
+	return Marshal(v)
+}
+
+func encodeValue(buf *bytes.Buffer, v interface{}) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if val {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case float64:
+		buf.WriteString(formatNumber(val))
+	case json.Number:
+		f, err := val.Float64()
+		if err != nil {
+			return fmt.Errorf("canonical: invalid number %q: %w", val, err)
+		}
+		buf.WriteString(formatNumber(f))
+	case string:
+		writeString(buf, val)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range val {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeValue(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return lessUTF16(keys[i], keys[j]) })
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, k)
+			buf.WriteByte(':')
+			if err := encodeValue(buf, val[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("canonical: unsupported type %T", v)
+	}
+	return nil
+}
+
+// lessUTF16 orders a and b by their UTF-16 code units, per RFC 8785's key-ordering rule.
+// This differs from a plain Go string (byte/UTF-8) comparison for astral characters
+// (surrogate pairs), which sort lower in UTF-16 than most BMP characters above U+D7FF.
+func lessUTF16(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// writeString writes s as a JSON string literal using JCS's minimal escape set: the
+// required \" \\ \b \f \n \r \t shorthands, \u00XX for other control characters, and
+// every other character (including non-ASCII) written out as raw UTF-8.
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// formatNumber renders f per the ECMA-262 Number::toString algorithm: the shortest
+// round-trip decimal, no trailing ".0", and exponential form only for |f| >= 1e21 or
+// 0 < |f| < 1e-6. -0 is normalized to "0" as JCS requires.
+func formatNumber(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "0"
+	}
+	if f == 0 {
+		return "0"
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	digits, exp := shortestDigits(f)
+	return sign + ecmaFormat(digits, exp+1)
+}
+
+// shortestDigits returns the shortest decimal digit string that round-trips to f (no
+// leading/trailing zeros beyond what's significant) along with exp such that
+// f == digits[0].digits[1:] * 10^exp (i.e. digits[0] occupies the 10^exp place).
+func shortestDigits(f float64) (string, int) {
+	// 'e' with prec -1 gives Go's shortest round-tripping mantissa/exponent form,
+	// e.g. "1.23456e+02" or "5e+00".
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, expPart, _ := strings.Cut(s, "e")
+	exp, _ := strconv.Atoi(expPart)
+
+	digits := strings.Replace(mantissa, ".", "", 1)
+	return digits, exp
+}
+
+// ecmaFormat implements steps 5-8 of ECMA-262's Number::toString(10): given the
+// significant digits s (k of them) and exponent n such that the value equals
+// s * 10^(n-k), render the digits with a decimal point, leading zeros, trailing
+// zeros, or exponential notation as the spec dictates.
+func ecmaFormat(digits string, n int) string {
+	k := len(digits)
+
+	switch {
+	case k <= n && n <= 21:
+		return digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		return digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		return "0." + strings.Repeat("0", -n) + digits
+	default:
+		exp := n - 1
+		sign := "+"
+		if exp < 0 {
+			sign = "-"
+			exp = -exp
+		}
+		mantissa := digits[:1]
+		if k > 1 {
+			mantissa += "." + digits[1:]
+		}
+		return fmt.Sprintf("%se%s%d", mantissa, sign, exp)
+	}
+}
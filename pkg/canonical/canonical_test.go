@@ -0,0 +1,102 @@
+package canonical
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+	cases := []struct {
+		name string
+		in   float64
+		want string
+	}{
+		{"zero", 0, "0"},
+		{"negativeZero", negZero(), "0"},
+		{"integer", 333333333, "333333333"},
+		{"noTrailingDotZero", 1, "1"},
+		{"fraction", 333333333.33333329, "333333333.3333333"},
+		{"smallFraction", 0.1, "0.1"},
+		{"negative", -1.5, "-1.5"},
+		{"largeExponent", 1e21, "1e+21"},
+		{"justBelowLargeExponent", 999999999999999900000, "999999999999999900000"},
+		{"smallExponent", 1e-7, "1e-7"},
+		{"justAboveSmallExponent", 1e-6, "0.000001"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := formatNumber(c.in)
+			if got != c.want {
+				t.Errorf("formatNumber(%v) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func negZero() float64 {
+	return -0.0
+}
+
+func TestMarshalKeyOrdering(t *testing.T) {
+	// JCS orders object keys by UTF-16 code unit, so a surrogate-pair (astral) key like
+	// the emoji below sorts after BMP characters such as "é", because its UTF-16 encoding
+	// starts with a high surrogate (0xD83C), above 0x00E9.
+	doc := map[string]interface{}{
+		"é": 1.0, // U+00E9, BMP
+		"🎉": 2.0, // U+1F389, astral
+		"z": 3.0, // U+007A, BMP
+	}
+
+	got, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	want := `{"z":3,"é":1,"🎉":2}`
+	if string(got) != want {
+		t.Fatalf("Marshal key order = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalStringEscaping(t *testing.T) {
+	controlByte := byte(0x01)
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"quoteAndBackslash", `a"b\c`, `"a\"b\\c"`},
+		{"controlChars", "a\nb\tc\rd", `"a\nb\tc\rd"`},
+		{"otherControl", string(controlByte), `"\u0001"`},
+		{"nonASCIINotEscaped", "héllo", `"héllo"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Marshal(c.in)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(got) != c.want {
+				t.Errorf("Marshal(%q) = %s, want %s", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	// Vector adapted from the RFC 8785 appendix: unordered keys, a float that needs
+	// shortest round-trip formatting, and insignificant whitespace in the source.
+	src := []byte(`{
+		"b": 2,
+		"a": 1,
+		"c": 333333333.33333329
+	}`)
+	want := `{"a":1,"b":2,"c":333333333.3333333}`
+
+	got, err := Transform(src)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("Transform = %s, want %s", got, want)
+	}
+}
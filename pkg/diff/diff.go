@@ -0,0 +1,172 @@
+// Package diff computes a structural patch between two decoded JSON values.
+package diff
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op is a single RFC 6902 JSON Patch operation.
+type Op struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Diff compares a and b (trees of the shape encoding/json.Unmarshal produces into
+// interface{}) and returns the RFC 6902 patch that turns a into b. Objects are compared
+// by the union of their keys; arrays are aligned with a longest-common-subsequence so
+// that reordered or appended elements don't show up as a full replace; scalars that
+// differ produce a single "replace".
+func Diff(a, b interface{}) []Op {
+	return diffValue("", a, b)
+}
+
+func diffValue(path string, a, b interface{}) []Op {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			return diffObjects(path, av, bv)
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			return diffArrays(path, av, bv)
+		}
+	}
+
+	// A nil at the root stands for a missing document (runDiffMode passes nil once one
+	// NDJSON stream ends before the other); below the root, nil is a JSON null and is
+	// compared like any other scalar.
+	if path == "" && a == nil {
+		return []Op{{Op: "add", Path: path, Value: b}}
+	}
+	if path == "" && b == nil {
+		return []Op{{Op: "remove", Path: path}}
+	}
+	return []Op{{Op: "replace", Path: path, Value: b}}
+}
+
+func diffObjects(path string, a, b map[string]interface{}) []Op {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var ops []Op
+	for _, k := range sorted {
+		childPath := path + "/" + escapePointerToken(k)
+		av, aok := a[k]
+		bv, bok := b[k]
+		switch {
+		case aok && !bok:
+			ops = append(ops, Op{Op: "remove", Path: childPath})
+		case !aok && bok:
+			ops = append(ops, Op{Op: "add", Path: childPath, Value: bv})
+		default:
+			ops = append(ops, diffValue(childPath, av, bv)...)
+		}
+	}
+	return ops
+}
+
+// diffArrays aligns a and b with a longest-common-subsequence over deep-equal elements,
+// then emits remove/add ops for the elements outside that subsequence. Positions are
+// tracked left-to-right so indices stay valid if the ops are applied in order.
+func diffArrays(path string, a, b []interface{}) []Op {
+	script := lcsEditScript(a, b)
+
+	var ops []Op
+	idx := 0
+	for _, e := range script {
+		switch e.kind {
+		case editMatch:
+			idx++
+		case editDelete:
+			ops = append(ops, Op{Op: "remove", Path: path + "/" + strconv.Itoa(idx)})
+		case editInsert:
+			ops = append(ops, Op{Op: "add", Path: path + "/" + strconv.Itoa(idx), Value: b[e.bIdx]})
+			idx++
+		}
+	}
+	return ops
+}
+
+type editKind int
+
+const (
+	editMatch editKind = iota
+	editDelete
+	editInsert
+)
+
+type editEntry struct {
+	kind editKind
+	aIdx int
+	bIdx int
+}
+
+// lcsEditScript computes a minimal match/delete/insert script turning a into b, using a
+// classic dynamic-programming longest-common-subsequence over deep-equal elements.
+func lcsEditScript(a, b []interface{}) []editEntry {
+	n, m := len(a), len(b)
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if reflect.DeepEqual(a[i], b[j]) {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var script []editEntry
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case reflect.DeepEqual(a[i], b[j]):
+			script = append(script, editEntry{kind: editMatch, aIdx: i, bIdx: j})
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			script = append(script, editEntry{kind: editDelete, aIdx: i})
+			i++
+		default:
+			script = append(script, editEntry{kind: editInsert, bIdx: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		script = append(script, editEntry{kind: editDelete, aIdx: i})
+	}
+	for ; j < m; j++ {
+		script = append(script, editEntry{kind: editInsert, bIdx: j})
+	}
+	return script
+}
+
+// escapePointerToken escapes a raw object key per RFC 6901 (~ -> ~0, / -> ~1).
+func escapePointerToken(tok string) string {
+	tok = strings.ReplaceAll(tok, "~", "~0")
+	tok = strings.ReplaceAll(tok, "/", "~1")
+	return tok
+}
@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffObjectAddRemoveReplace(t *testing.T) {
+	a := map[string]interface{}{"keep": 1, "removed": 2, "changed": 3}
+	b := map[string]interface{}{"keep": 1, "changed": 4, "added": 5}
+
+	got := Diff(a, b)
+	want := []Op{
+		{Op: "add", Path: "/added", Value: 5},
+		{Op: "replace", Path: "/changed", Value: 4},
+		{Op: "remove", Path: "/removed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffArrayInsertDelete(t *testing.T) {
+	a := []interface{}{"a", "b", "c"}
+	b := []interface{}{"a", "c", "d"}
+
+	got := Diff(a, b)
+	want := []Op{
+		{Op: "remove", Path: "/1"},
+		{Op: "add", Path: "/2", Value: "d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffArrayReorder(t *testing.T) {
+	a := []interface{}{"a", "b", "c"}
+	b := []interface{}{"c", "a", "b"}
+
+	got := Diff(a, b)
+	want := []Op{
+		{Op: "add", Path: "/0", Value: "c"},
+		{Op: "remove", Path: "/3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	a := map[string]interface{}{"a": []interface{}{1, 2, 3}}
+	b := map[string]interface{}{"a": []interface{}{1, 2, 3}}
+
+	if got := Diff(a, b); got != nil {
+		t.Fatalf("Diff(identical) = %#v, want nil", got)
+	}
+}
+
+func TestEscapePointerTokenTildeAndSlash(t *testing.T) {
+	cases := []struct {
+		tok  string
+		want string
+	}{
+		{"a~b", "a~0b"},
+		{"a/b", "a~1b"},
+		{"~/", "~0~1"},
+		{"plain", "plain"},
+	}
+	for _, c := range cases {
+		if got := escapePointerToken(c.tok); got != c.want {
+			t.Errorf("escapePointerToken(%q) = %q, want %q", c.tok, got, c.want)
+		}
+	}
+}
+
+func TestDiffEscapesPointerTokensInObjectKeys(t *testing.T) {
+	a := map[string]interface{}{"a/b": 1, "c~d": 2}
+	b := map[string]interface{}{}
+
+	got := Diff(a, b)
+	want := []Op{
+		{Op: "remove", Path: "/a~1b"},
+		{Op: "remove", Path: "/c~0d"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/gigachad80/JSON-Beautify/pkg/gron"
+)
+
+// Line is one row of a gron-style diff: an unchanged ("  "), added ("+ "), or removed
+// ("- ") assignment. Changed values are represented as a removed line for the old value
+// immediately followed by an added line for the new one.
+type Line struct {
+	Sign string // " ", "+", or "-"
+	Text string // "path = literal;" without the sign
+}
+
+// GronLines flattens a and b into gron-style path assignments and returns only the lines
+// that differ, each tagged with a +/- sign. Unchanged assignments are suppressed.
+func GronLines(a, b interface{}) []Line {
+	flatA := flattenToMap("json", a)
+	flatB := flattenToMap("json", b)
+
+	paths := map[string]bool{}
+	for p := range flatA {
+		paths[p] = true
+	}
+	for p := range flatB {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var lines []Line
+	for _, p := range sorted {
+		va, aok := flatA[p]
+		vb, bok := flatB[p]
+		switch {
+		case aok && !bok:
+			lines = append(lines, Line{Sign: "-", Text: p + " = " + va + ";"})
+		case !aok && bok:
+			lines = append(lines, Line{Sign: "+", Text: p + " = " + vb + ";"})
+		case va != vb:
+			lines = append(lines, Line{Sign: "-", Text: p + " = " + va + ";"})
+			lines = append(lines, Line{Sign: "+", Text: p + " = " + vb + ";"})
+		}
+	}
+	return lines
+}
+
+// flattenToMap flattens v into a path -> JSON literal map, using the same gron-flatten
+// logic -gron uses so the two can't drift out of sync with each other.
+func flattenToMap(prefix string, v interface{}) map[string]string {
+	out := map[string]string{}
+	for _, a := range gron.Flatten(prefix, v) {
+		out[a.Path] = a.Literal
+	}
+	return out
+}
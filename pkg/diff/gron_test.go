@@ -0,0 +1,25 @@
+package diff
+
+import "testing"
+
+func TestGronLinesEmptyContainerChange(t *testing.T) {
+	var a interface{} = map[string]interface{}{}
+	var b interface{} = map[string]interface{}{"foo": map[string]interface{}{}}
+
+	lines := GronLines(a, b)
+	if len(lines) != 1 {
+		t.Fatalf("GronLines(%v, %v) = %v, want exactly one added line", a, b, lines)
+	}
+	if lines[0].Sign != "+" || lines[0].Text != "json.foo = {};" {
+		t.Fatalf("GronLines(%v, %v) = %+v, want {Sign: \"+\", Text: \"json.foo = {};\"}", a, b, lines[0])
+	}
+}
+
+func TestGronLinesIdenticalEmptyContainers(t *testing.T) {
+	var a interface{} = map[string]interface{}{}
+	var b interface{} = map[string]interface{}{}
+
+	if lines := GronLines(a, b); len(lines) != 0 {
+		t.Fatalf("GronLines(%v, %v) = %v, want no lines for identical documents", a, b, lines)
+	}
+}
@@ -0,0 +1,73 @@
+// Package gron flattens a decoded JSON value into gron-style path/literal assignments
+// (https://github.com/tomnomnom/gron), shared by the CLI's -gron mode and the -diff
+// -diff-format gron dialect so the two can't drift out of sync with each other.
+package gron
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Assignment is one "path = literal" pair produced by flattening a JSON document. Literal
+// is the right-hand side exactly as it should appear before the trailing ";" — a JSON
+// literal for scalars, or "{}"/"[]" for a container's preamble entry.
+type Assignment struct {
+	Path    string
+	Literal string
+}
+
+// reBareKey matches identifiers that can be written as json.foo instead of json["foo"].
+var reBareKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Flatten walks v and returns every gron assignment rooted at prefix (conventionally
+// "json"), in depth-first order. Objects and arrays each get a preamble assignment
+// ("prefix = {};" / "prefix = [];") before their children, so a structural change
+// affecting only an empty container still produces an assignment.
+func Flatten(prefix string, v interface{}) []Assignment {
+	var out []Assignment
+	flatten(prefix, v, &out)
+	return out
+}
+
+func flatten(prefix string, v interface{}, out *[]Assignment) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		*out = append(*out, Assignment{Path: prefix, Literal: "{}"})
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flatten(ChildPath(prefix, k), val[k], out)
+		}
+	case []interface{}:
+		*out = append(*out, Assignment{Path: prefix, Literal: "[]"})
+		for i, elem := range val {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), elem, out)
+		}
+	default:
+		*out = append(*out, Assignment{Path: prefix, Literal: literal(val)})
+	}
+}
+
+// ChildPath appends a key segment to a path, using bare-identifier form when possible and
+// bracket-quoted form otherwise (e.g. json["weird key"]).
+func ChildPath(prefix, key string) string {
+	if reBareKey.MatchString(key) {
+		return prefix + "." + key
+	}
+	encoded, _ := json.Marshal(key)
+	return prefix + "[" + string(encoded) + "]"
+}
+
+// literal renders a scalar as the JSON literal used on the right-hand side of an assignment.
+func literal(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
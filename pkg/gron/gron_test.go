@@ -0,0 +1,67 @@
+package gron
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(`{"a":1,"b":[2,3],"c":{}}`), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got := Flatten("json", doc)
+	want := []Assignment{
+		{Path: "json", Literal: "{}"},
+		{Path: "json.a", Literal: "1"},
+		{Path: "json.b", Literal: "[]"},
+		{Path: "json.b[0]", Literal: "2"},
+		{Path: "json.b[1]", Literal: "3"},
+		{Path: "json.c", Literal: "{}"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Flatten = %#v, want %#v", got, want)
+	}
+}
+
+// TestFlattenPreservesNumberSourceText checks that Flatten renders numbers from the exact
+// source token (via json.Number) rather than round-tripping them through float64, which
+// would silently corrupt integers beyond 2^53 and drop trailing-zero decimal formatting.
+func TestFlattenPreservesNumberSourceText(t *testing.T) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(`{"id":9007199254740993,"price":19.990}`)))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	got := Flatten("json", doc)
+	want := []Assignment{
+		{Path: "json", Literal: "{}"},
+		{Path: "json.id", Literal: "9007199254740993"},
+		{Path: "json.price", Literal: "19.990"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Flatten = %#v, want %#v", got, want)
+	}
+}
+
+func TestChildPath(t *testing.T) {
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{"foo", "json.foo"},
+		{"weird key", `json["weird key"]`},
+		{"with.dot", `json["with.dot"]`},
+	}
+	for _, c := range cases {
+		if got := ChildPath("json", c.key); got != c.want {
+			t.Errorf("ChildPath(%q) = %q, want %q", c.key, got, c.want)
+		}
+	}
+}
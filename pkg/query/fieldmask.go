@@ -0,0 +1,110 @@
+package query
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldMask is a parsed AIP-157-style field mask: a set of dotted paths (with optional "*"
+// wildcard segments) describing which parts of a document to keep.
+type FieldMask struct {
+	root *maskNode
+}
+
+type maskNode struct {
+	leaf     bool
+	children map[string]*maskNode
+}
+
+// ParseFieldMask parses a comma-separated list of dotted paths such as
+// "user.name,items.*.price" into a FieldMask.
+func ParseFieldMask(s string) FieldMask {
+	root := &maskNode{children: map[string]*maskNode{}}
+	for _, path := range strings.Split(s, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := root
+		segs := strings.Split(path, ".")
+		for _, seg := range segs {
+			child, ok := node.children[seg]
+			if !ok {
+				child = &maskNode{children: map[string]*maskNode{}}
+				node.children[seg] = child
+			}
+			node = child
+		}
+		node.leaf = true
+	}
+	return FieldMask{root: root}
+}
+
+// Prune returns the subset of doc selected by the mask. Object keys and array elements
+// not reachable through any mask path are dropped; a node with no further children is
+// kept in full once reached.
+func (m FieldMask) Prune(doc interface{}) interface{} {
+	return pruneNode(doc, m.root)
+}
+
+func pruneNode(doc interface{}, node *maskNode) interface{} {
+	if node == nil {
+		return nil
+	}
+	// A leaf mask path means "keep everything here", even if a deeper path under the same
+	// key was also masked (e.g. "a,a.b"): the broader "a" wins and its whole subtree is kept.
+	if node.leaf {
+		return doc
+	}
+
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		if wildcard, ok := node.children["*"]; ok {
+			for k, val := range v {
+				out[k] = pruneNode(val, wildcard)
+			}
+		}
+		for key, child := range node.children {
+			if key == "*" {
+				continue
+			}
+			if val, ok := v[key]; ok {
+				out[key] = pruneNode(val, child)
+			}
+		}
+		return out
+
+	case []interface{}:
+		if wildcard, ok := node.children["*"]; ok {
+			out := make([]interface{}, len(v))
+			for i, elem := range v {
+				out[i] = pruneNode(elem, wildcard)
+			}
+			return out
+		}
+		type indexedChild struct {
+			idx   int
+			child *maskNode
+		}
+		indexed := make([]indexedChild, 0, len(node.children))
+		for key, child := range node.children {
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				continue
+			}
+			indexed = append(indexed, indexedChild{idx, child})
+		}
+		sort.Slice(indexed, func(i, j int) bool { return indexed[i].idx < indexed[j].idx })
+
+		out := make([]interface{}, 0, len(indexed))
+		for _, ic := range indexed {
+			out = append(out, pruneNode(v[ic.idx], ic.child))
+		}
+		return out
+
+	default:
+		return v
+	}
+}
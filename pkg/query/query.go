@@ -0,0 +1,257 @@
+// Package query implements a small gjson/JSONPath-like query language for navigating
+// decoded JSON values (the interface{} trees produced by encoding/json).
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned (and wrapped) when a path segment has nothing to match, e.g. a
+// missing object key or an out-of-range array index.
+var ErrNotFound = errors.New("query: path not found")
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepIndex
+	stepWildcard
+	stepFilter
+)
+
+type step struct {
+	kind   stepKind
+	field  string
+	index  int
+	filter filter
+}
+
+type filter struct {
+	field string
+	op    string
+	value string
+}
+
+// Path is a parsed query expression, ready to be applied to a decoded document with Apply.
+type Path struct {
+	steps []step
+}
+
+// Parse compiles a dotted query expression into a Path. Supported segments:
+//
+//	name          object field access
+//	0             array index
+//	* or #        wildcard over every array element
+//	#(field>10)   filter: keep array elements matching the comparison, then continue
+//	              the rest of the path against each match
+func Parse(expr string) (Path, error) {
+	var p Path
+	if expr == "" {
+		return p, nil
+	}
+	for _, seg := range splitSegments(expr) {
+		s, err := parseSegment(seg)
+		if err != nil {
+			return Path{}, fmt.Errorf("query: %w", err)
+		}
+		p.steps = append(p.steps, s)
+	}
+	return p, nil
+}
+
+// splitSegments splits expr on '.' while treating the contents of #(...) as opaque, so a
+// filter value is never accidentally split.
+func splitSegments(expr string) []string {
+	var segs []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '.':
+			if depth == 0 {
+				segs = append(segs, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	segs = append(segs, expr[start:])
+	return segs
+}
+
+var filterOps = []string{">=", "<=", "!=", "==", "=", ">", "<"}
+
+func parseSegment(seg string) (step, error) {
+	switch {
+	case seg == "*" || seg == "#":
+		return step{kind: stepWildcard}, nil
+
+	case strings.HasPrefix(seg, "#(") && strings.HasSuffix(seg, ")"):
+		inner := seg[2 : len(seg)-1]
+		for _, op := range filterOps {
+			if idx := strings.Index(inner, op); idx >= 0 {
+				return step{
+					kind: stepFilter,
+					filter: filter{
+						field: strings.TrimSpace(inner[:idx]),
+						op:    op,
+						value: strings.TrimSpace(inner[idx+len(op):]),
+					},
+				}, nil
+			}
+		}
+		return step{}, fmt.Errorf("invalid filter %q: no comparison operator", seg)
+
+	default:
+		if n, err := strconv.Atoi(seg); err == nil {
+			return step{kind: stepIndex, index: n}, nil
+		}
+		return step{kind: stepField, field: seg}, nil
+	}
+}
+
+// Apply navigates doc according to p and returns the matched value. Wildcard and filter
+// steps fan out: the remainder of the path is applied independently to every matching
+// element and the results are collected into a slice.
+func Apply(doc interface{}, p Path) (interface{}, error) {
+	return applySteps(doc, p.steps)
+}
+
+func applySteps(doc interface{}, steps []step) (interface{}, error) {
+	if len(steps) == 0 {
+		return doc, nil
+	}
+	s := steps[0]
+	rest := steps[1:]
+
+	switch s.kind {
+	case stepField:
+		m, ok := doc.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not an object", ErrNotFound, s.field)
+		}
+		val, ok := m[s.field]
+		if !ok {
+			return nil, fmt.Errorf("%w: no field %q", ErrNotFound, s.field)
+		}
+		return applySteps(val, rest)
+
+	case stepIndex:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: index %d on non-array", ErrNotFound, s.index)
+		}
+		if s.index < 0 || s.index >= len(arr) {
+			return nil, fmt.Errorf("%w: index %d out of range", ErrNotFound, s.index)
+		}
+		return applySteps(arr[s.index], rest)
+
+	case stepWildcard:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: wildcard on non-array", ErrNotFound)
+		}
+		return applyToEach(arr, rest)
+
+	case stepFilter:
+		arr, ok := doc.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: filter on non-array", ErrNotFound)
+		}
+		var matched []interface{}
+		for _, elem := range arr {
+			if matchesFilter(elem, s.filter) {
+				matched = append(matched, elem)
+			}
+		}
+		return applyToEach(matched, rest)
+	}
+
+	return nil, fmt.Errorf("query: unknown step")
+}
+
+// applyToEach applies rest to every element of arr, skipping elements where rest resolves
+// to nothing, and collects the results into a slice.
+func applyToEach(arr []interface{}, rest []step) (interface{}, error) {
+	results := make([]interface{}, 0, len(arr))
+	for _, elem := range arr {
+		v, err := applySteps(elem, rest)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+func matchesFilter(elem interface{}, f filter) bool {
+	m, ok := elem.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	actual, ok := m[f.field]
+	if !ok {
+		return false
+	}
+
+	switch v := actual.(type) {
+	case json.Number:
+		if af, err := v.Float64(); err == nil {
+			if wf, err := strconv.ParseFloat(f.value, 64); err == nil {
+				return compareFloat(af, f.op, wf)
+			}
+		}
+	case float64:
+		if wf, err := strconv.ParseFloat(f.value, 64); err == nil {
+			return compareFloat(v, f.op, wf)
+		}
+	}
+
+	return compareString(fmt.Sprintf("%v", actual), f.op, f.value)
+}
+
+func compareFloat(a float64, op string, b float64) bool {
+	switch op {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case "=", "==":
+		return a == b
+	case "!=":
+		return a != b
+	}
+	return false
+}
+
+func compareString(a, op, b string) bool {
+	switch op {
+	case "=", "==":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
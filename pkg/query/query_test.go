@@ -0,0 +1,168 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustUnmarshal(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("unmarshal %q: %v", s, err)
+	}
+	return v
+}
+
+func TestApplyField(t *testing.T) {
+	doc := mustUnmarshal(t, `{"user":{"name":"ada"}}`)
+	p, err := Parse("user.name")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := Apply(doc, p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got != "ada" {
+		t.Fatalf("Apply = %v, want %q", got, "ada")
+	}
+}
+
+func TestApplyIndexAndWildcard(t *testing.T) {
+	doc := mustUnmarshal(t, `{"items":[{"name":"a"},{"name":"b"}]}`)
+
+	p, err := Parse("items.0.name")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := Apply(doc, p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got != "a" {
+		t.Fatalf("Apply items.0.name = %v, want %q", got, "a")
+	}
+
+	p, err = Parse("items.#.name")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err = Apply(doc, p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	want := []interface{}{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Apply items.#.name = %v, want %v", got, want)
+	}
+}
+
+func TestApplyFilter(t *testing.T) {
+	doc := mustUnmarshal(t, `{"items":[{"name":"a","price":5},{"name":"b","price":15}]}`)
+
+	p, err := Parse("items.#(price>10).name")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := Apply(doc, p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	want := []interface{}{"b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Apply filter = %v, want %v", got, want)
+	}
+}
+
+// TestApplyFilterUseNumber is a regression test: the real CLI decode path
+// (main.go) uses a json.Decoder with UseNumber set, so filter comparisons must
+// work against json.Number, not just the float64 plain json.Unmarshal
+// produces. Building the fixture with UseNumber here reproduces that path
+// instead of masking the bug behind float64.
+func TestApplyFilterUseNumber(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"items":[{"name":"a","price":5},{"name":"b","price":15},{"name":"c","price":9}]}`))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	p, err := Parse("items.#(price>10).name")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got, err := Apply(doc, p)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	want := []interface{}{"b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Apply filter (UseNumber) = %v, want %v", got, want)
+	}
+}
+
+func TestApplyNotFound(t *testing.T) {
+	doc := mustUnmarshal(t, `{"a":1}`)
+	p, err := Parse("b")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := Apply(doc, p); err == nil {
+		t.Fatalf("Apply(missing field) = nil error, want ErrNotFound")
+	}
+}
+
+func TestPruneBasic(t *testing.T) {
+	doc := mustUnmarshal(t, `{"user":{"name":"ada","age":36},"items":[{"price":1},{"price":2}]}`)
+	mask := ParseFieldMask("user.name,items.*.price")
+
+	got := mask.Prune(doc)
+	want := mustUnmarshal(t, `{"user":{"name":"ada"},"items":[{"price":1},{"price":2}]}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Prune = %#v, want %#v", got, want)
+	}
+}
+
+// TestPruneOverlappingLeafAndChildren is a regression test: a mask that names both a key
+// and a deeper path under it (e.g. "a,a.b") means "keep everything under a", not just the
+// nested path, since the broader mask subsumes the narrower one.
+func TestPruneOverlappingLeafAndChildren(t *testing.T) {
+	doc := mustUnmarshal(t, `{"a":{"b":1,"c":2},"d":3}`)
+	mask := ParseFieldMask("a,a.b")
+
+	got := mask.Prune(doc)
+	want := mustUnmarshal(t, `{"a":{"b":1,"c":2}}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Prune(overlapping mask) = %#v, want %#v", got, want)
+	}
+}
+
+// TestPruneMultipleArrayIndicesPreservesOrder is a regression test: pruning an array with
+// several explicit numeric indices in the mask must emit elements in ascending index order,
+// not the randomized order the mask's children map would otherwise iterate in.
+func TestPruneMultipleArrayIndicesPreservesOrder(t *testing.T) {
+	doc := mustUnmarshal(t, `{"items":["a","b","c","d","e"]}`)
+	mask := ParseFieldMask("items.0,items.1,items.2")
+
+	want := mustUnmarshal(t, `{"items":["a","b","c"]}`)
+	for i := 0; i < 20; i++ {
+		got := mask.Prune(doc)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Prune = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestPruneMissingKeyDropped(t *testing.T) {
+	doc := mustUnmarshal(t, `{"a":1}`)
+	mask := ParseFieldMask("b")
+
+	got := mask.Prune(doc)
+	want := mustUnmarshal(t, `{}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Prune(missing key) = %#v, want %#v", got, want)
+	}
+}
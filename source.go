@@ -0,0 +1,182 @@
+package main
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Source is anything main can read a JSON stream from. Adding a new input scheme (e.g.
+// s3://, file://) only requires a new implementation and a dispatch case in resolveSources.
+type Source interface {
+	// Open returns a reader for the source's content, a human-readable label for
+	// progress output, and any error encountered while opening it.
+	Open() (io.ReadCloser, string, error)
+}
+
+// fileSource reads from a path on disk.
+type fileSource struct {
+	path string
+}
+
+func (s fileSource) Open() (io.ReadCloser, string, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, "", fmt.Errorf("opening %s: %w", s.path, err)
+	}
+	return f, s.path, nil
+}
+
+// stdinSource reads from os.Stdin.
+type stdinSource struct{}
+
+func (stdinSource) Open() (io.ReadCloser, string, error) {
+	return io.NopCloser(os.Stdin), "", nil
+}
+
+// headerList collects repeated -H "Key: value" flags into an http.Header.
+type headerList struct {
+	http.Header
+}
+
+func (h *headerList) String() string {
+	if h == nil || h.Header == nil {
+		return ""
+	}
+	return fmt.Sprint(h.Header)
+}
+
+func (h *headerList) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("invalid header %q: expected \"Key: value\"", value)
+	}
+	if h.Header == nil {
+		h.Header = http.Header{}
+	}
+	h.Header.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	return nil
+}
+
+var (
+	httpTimeout = flag.Duration("timeout", 30*time.Second, "Timeout for http:// / https:// inputs")
+	httpHeaders = func() *headerList {
+		h := &headerList{}
+		flag.Var(h, "H", "Extra request header for URL inputs (repeatable, \"Key: value\")")
+		return h
+	}()
+	insecureTLS = flag.Bool("insecure", false, "Skip TLS certificate verification for https:// inputs")
+	sep         = flag.String("sep", "\n", "Separator written between output documents")
+	keepGoing   = flag.Bool("keep-going", false, "Log errors from one input and continue with the next instead of aborting")
+)
+
+// httpSource fetches JSON from a remote URL, honoring -timeout, -H, -insecure, and
+// transparently decoding gzip/deflate bodies based on Content-Encoding.
+type httpSource struct {
+	url string
+}
+
+func (s httpSource) Open() (io.ReadCloser, string, error) {
+	req, err := http.NewRequest(http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("building request for %s: %w", s.url, err)
+	}
+	for key, values := range httpHeaders.Header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	client := &http.Client{
+		Timeout: *httpTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: *insecureTLS},
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching %s: %w", s.url, err)
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("fetching %s: server returned %s", s.url, resp.Status)
+	}
+
+	body, err := decodeContentEncoding(resp.Body, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("decoding %s: %w", s.url, err)
+	}
+
+	return body, s.url, nil
+}
+
+// decodeContentEncoding wraps body in a gzip/zlib reader when encoding calls for it,
+// closing the underlying body once the wrapper is closed.
+func decodeContentEncoding(body io.ReadCloser, encoding string) (io.ReadCloser, error) {
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		return wrapCloser{Reader: gz, underlying: body}, nil
+	case "deflate":
+		zr, err := zlib.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		return wrapCloser{Reader: zr, underlying: body}, nil
+	default:
+		return body, nil
+	}
+}
+
+// wrapCloser adapts a decoding io.Reader so closing it also closes the original body.
+type wrapCloser struct {
+	io.Reader
+	underlying io.ReadCloser
+}
+
+func (w wrapCloser) Close() error {
+	return w.underlying.Close()
+}
+
+// resolveSources turns -i / positional arguments into the ordered list of Sources to
+// process. With nothing specified, it falls back to stdin (erroring if stdin is a TTY).
+func resolveSources() ([]Source, error) {
+	var specs []string
+	if *inputFile != "" {
+		specs = append(specs, *inputFile)
+	}
+	specs = append(specs, flag.Args()...)
+
+	if len(specs) == 0 {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			return nil, fmt.Errorf("no input provided. Use -i, pass a file/URL, or pipe data")
+		}
+		return []Source{stdinSource{}}, nil
+	}
+
+	sources := make([]Source, 0, len(specs))
+	for _, spec := range specs {
+		switch {
+		case spec == "-":
+			sources = append(sources, stdinSource{})
+		case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+			sources = append(sources, httpSource{url: spec})
+		default:
+			sources = append(sources, fileSource{path: spec})
+		}
+	}
+	return sources, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// runMainSubprocess runs this test binary as a "json-beautify <flags...>" subprocess (reusing
+// the TestHelperProcess re-exec defined in diffmode_test.go) and returns its stdout and exit
+// code, so -keep-going's "log and continue" contract can be observed without os.Exit killing
+// the real test binary.
+func runMainSubprocess(t *testing.T, flags ...string) (string, int) {
+	t.Helper()
+	args := append([]string{"-test.run=^TestHelperProcess$", "--"}, flags...)
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "JSON_BEAUTIFY_HELPER_PROCESS=1")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	if err == nil {
+		return stdout.String(), 0
+	}
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("subprocess failed to run: %v", err)
+	}
+	return stdout.String(), exitErr.ExitCode()
+}
+
+// TestHTTPSourceGzip checks that httpSource transparently inflates a gzip-encoded body
+// based on Content-Encoding, the way a gzip-serving API would respond.
+func TestHTTPSourceGzip(t *testing.T) {
+	want := `{"a":1}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(want))
+		gz.Close()
+	}))
+	defer srv.Close()
+
+	rc, label, err := (httpSource{url: srv.URL}).Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer rc.Close()
+	if label != srv.URL {
+		t.Fatalf("label = %q, want %q", label, srv.URL)
+	}
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("gzip body = %q, want %q", got, want)
+	}
+}
+
+// TestHTTPSourceDeflate mirrors TestHTTPSourceGzip for a zlib/"deflate"-encoded body.
+func TestHTTPSourceDeflate(t *testing.T) {
+	want := `{"b":2}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		zw := zlib.NewWriter(w)
+		zw.Write([]byte(want))
+		zw.Close()
+	}))
+	defer srv.Close()
+
+	rc, _, err := (httpSource{url: srv.URL}).Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if string(got) != want {
+		t.Fatalf("deflate body = %q, want %q", got, want)
+	}
+}
+
+// TestKeepGoingSkipsBadSourceAndProcessesTheRest is a regression test for -keep-going: one
+// unreadable source (a missing file) must be logged and skipped rather than aborting the
+// whole run, and the next source must still be processed and exit 0.
+func TestKeepGoingSkipsBadSourceAndProcessesTheRest(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "does-not-exist.json")
+	good := writeTempJSON(t, dir, "good.json", `{"a":1}`)
+
+	stdout, code := runMainSubprocess(t, "-i", missing, "-keep-going", "-c", good)
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0 when -keep-going is set", code)
+	}
+	if !bytes.Contains([]byte(stdout), []byte(`{"a":1}`)) {
+		t.Fatalf("stdout = %q, want it to contain the good source's output", stdout)
+	}
+}
+
+// TestWithoutKeepGoingAbortsOnBadSource checks the default (non -keep-going) behavior: a
+// bad source aborts the run before later sources are processed.
+func TestWithoutKeepGoingAbortsOnBadSource(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "does-not-exist.json")
+	good := writeTempJSON(t, dir, "good.json", `{"a":1}`)
+
+	stdout, code := runMainSubprocess(t, "-i", missing, "-c", good)
+	if code != 1 {
+		t.Fatalf("exit code = %d, want 1 without -keep-going", code)
+	}
+	if bytes.Contains([]byte(stdout), []byte(`{"a":1}`)) {
+		t.Fatalf("stdout = %q, good source should not have been reached", stdout)
+	}
+}